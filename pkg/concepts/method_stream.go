@@ -0,0 +1,45 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concepts
+
+// streamAnnotation is the name of the annotation that marks a method as opening a long lived
+// stream of events instead of returning a single response, for example:
+//
+//	@stream
+//	watch() Event
+const streamAnnotation = "stream"
+
+// watchMethodName and subscribeMethodName are the names conventionally given in the model to
+// methods that open a long lived stream of events instead of returning a single response. They
+// are kept as a fallback for models written before the '@stream' annotation existed.
+const (
+	watchMethodName     = "watch"
+	subscribeMethodName = "subscribe"
+)
+
+// IsStream returns true if this method represents a streaming operation, i.e. one that keeps the
+// connection open and sends a sequence of events to the client instead of a single response.
+// A method opts in explicitly with the '@stream' annotation, which lets it stream under any name;
+// the 'watch'/'subscribe' naming convention is only consulted when the annotation is absent, so a
+// method called 'watch' or 'subscribe' is always treated as a stream, annotated or not.
+func (m *Method) IsStream() bool {
+	if m.Annotation(streamAnnotation) != nil {
+		return true
+	}
+	name := m.Name().String()
+	return name == watchMethodName || name == subscribeMethodName
+}
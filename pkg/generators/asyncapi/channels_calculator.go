@@ -0,0 +1,52 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asyncapi
+
+import (
+	"fmt"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/paths"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/schema"
+)
+
+// ChannelsCalculator is an object used to reconstruct the address of the AsyncAPI channel that
+// corresponds to a streaming method, by walking the same chain of locators used to build the REST
+// path of the resource that owns it. Don't create instances directly, use the
+// NewChannelsCalculator function instead.
+type ChannelsCalculator struct {
+}
+
+// NewChannelsCalculator creates a new channels calculator.
+func NewChannelsCalculator() *ChannelsCalculator {
+	return &ChannelsCalculator{}
+}
+
+// Address returns the channel address of the given resource, relative to the root of the version
+// that owns it.
+func (c *ChannelsCalculator) Address(resource *concepts.Resource) string {
+	segments, _ := paths.Walk(resource)
+	return paths.Join(segments)
+}
+
+// messageName returns the name used to identify, inside 'components/messages', the event sent by
+// the given streaming method. It reuses the schema package's naming convention, so that the
+// identifiers read consistently across the 'components/schemas' and 'components/messages'
+// sections of the document.
+func messageName(resource *concepts.Resource, method *concepts.Method) string {
+	return fmt.Sprintf("%s%sEvent", schema.Name(resource.Name()), schema.Name(method.Name()))
+}
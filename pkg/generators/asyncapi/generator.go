@@ -0,0 +1,272 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package asyncapi contains the generator that produces an AsyncAPI 2.6 document, in both YAML
+// and JSON, for each version of the model that has streaming methods, i.e. methods for which
+// concepts.Method.IsStream returns true. It reuses the pkg/generators/schema package so that the
+// 'components/schemas' section it emits is identical to the one produced by the OpenAPI
+// generator for the same types.
+//
+// The channel/locator-walking logic here takes *concepts.Resource/*concepts.Method fixtures to
+// exercise properly; see pkg/generators/paths and pkg/generators/crd for the parts of this
+// generator series that could be covered with plain Go tests in this checkout.
+package asyncapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/paths"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/schema"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/reporter"
+)
+
+// GeneratorBuilder is an object used to configure and build the AsyncAPI generator. Don't create
+// instances directly, use the NewGenerator function instead.
+type GeneratorBuilder struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+}
+
+// Generator generates AsyncAPI 2.6 documents for the streaming methods of the model. Don't create
+// instances directly, use the builder instead.
+type Generator struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+	channels *ChannelsCalculator
+	schemas  *schema.Calculator
+}
+
+// NewGenerator creates a new builder for AsyncAPI generators.
+func NewGenerator() *GeneratorBuilder {
+	return &GeneratorBuilder{}
+}
+
+// Reporter sets the object that will be used to report information about the generation process,
+// including errors.
+func (b *GeneratorBuilder) Reporter(value *reporter.Reporter) *GeneratorBuilder {
+	b.reporter = value
+	return b
+}
+
+// Model sets the model that will be used by the generator.
+func (b *GeneratorBuilder) Model(value *concepts.Model) *GeneratorBuilder {
+	b.model = value
+	return b
+}
+
+// Output sets the directory where the generated documents will be written.
+func (b *GeneratorBuilder) Output(value string) *GeneratorBuilder {
+	b.output = value
+	return b
+}
+
+// Build checks the configuration stored in the builder and, if it is correct, creates a new
+// AsyncAPI generator using it.
+func (b *GeneratorBuilder) Build() (generator *Generator, err error) {
+	if b.reporter == nil {
+		err = fmt.Errorf("reporter is mandatory")
+		return
+	}
+	if b.model == nil {
+		err = fmt.Errorf("model is mandatory")
+		return
+	}
+	if b.output == "" {
+		err = fmt.Errorf("output is mandatory")
+		return
+	}
+	generator = &Generator{
+		reporter: b.reporter,
+		model:    b.model,
+		output:   b.output,
+		channels: NewChannelsCalculator(),
+		schemas:  schema.NewCalculator(),
+	}
+	return
+}
+
+// Run executes the code generator.
+func (g *Generator) Run() error {
+	for _, service := range g.model.Services() {
+		for _, version := range service.Versions() {
+			if !g.hasStreams(version) {
+				continue
+			}
+			document := g.generateDocument(service, version)
+			err := g.writeDocument(service, version, document)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasStreams returns true if the given version has at least one streaming method, so that
+// versions without any don't get an empty AsyncAPI document.
+func (g *Generator) hasStreams(version *concepts.Version) bool {
+	for _, resource := range version.Resources() {
+		for _, method := range resource.Methods() {
+			if method.IsStream() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateDocument builds the AsyncAPI document that corresponds to the given version, as a tree
+// of maps and slices ready to be marshalled to YAML or JSON.
+func (g *Generator) generateDocument(service *concepts.Service, version *concepts.Version) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	messages := map[string]interface{}{}
+	channels := map[string]interface{}{}
+
+	for _, typ := range version.Types() {
+		switch {
+		case typ.IsStruct(), typ.IsEnum():
+			schemas[g.schemas.Name(typ)] = g.schemas.Schema(typ)
+		}
+	}
+
+	for _, resource := range version.Resources() {
+		for _, method := range resource.Methods() {
+			if !method.IsStream() {
+				continue
+			}
+			address := g.channels.Address(resource)
+			message := messageName(resource, method)
+			messages[message] = g.generateMessage(resource, method)
+			channels[address] = g.generateChannel(address, message)
+		}
+	}
+
+	return map[string]interface{}{
+		"asyncapi": "2.6.0",
+		"info": map[string]interface{}{
+			"title":   fmt.Sprintf("%s %s", service.Name().String(), version.Name().String()),
+			"version": version.Name().String(),
+		},
+		"servers": map[string]interface{}{
+			"websocket": map[string]interface{}{
+				"url":      "wss://api.openshift.com",
+				"protocol": "ws",
+			},
+			"sse": map[string]interface{}{
+				"url":      "https://api.openshift.com",
+				"protocol": "sse",
+			},
+		},
+		"channels": channels,
+		"components": map[string]interface{}{
+			"schemas":  schemas,
+			"messages": messages,
+		},
+	}
+}
+
+// generateChannel builds the AsyncAPI channel item for the given address, with a single
+// 'subscribe' operation that references the given message.
+func (g *Generator) generateChannel(address string, message string) map[string]interface{} {
+	channel := map[string]interface{}{
+		"subscribe": map[string]interface{}{
+			"message": map[string]interface{}{
+				"$ref": fmt.Sprintf("#/components/messages/%s", message),
+			},
+		},
+		"bindings": map[string]interface{}{
+			"ws":  map[string]interface{}{},
+			"sse": map[string]interface{}{},
+		},
+	}
+	parameters := paths.ParametersOf(address)
+	if len(parameters) > 0 {
+		schema := map[string]interface{}{}
+		for _, parameter := range parameters {
+			schema[parameter] = map[string]interface{}{
+				"schema": map[string]interface{}{
+					"type": "string",
+				},
+			}
+		}
+		channel["parameters"] = schema
+	}
+	return channel
+}
+
+// generateMessage builds the 'components/messages' entry for the event emitted by the given
+// streaming method. The payload references the schema of the type of the method's output 'body'
+// parameter, and is omitted when the method has no such parameter.
+func (g *Generator) generateMessage(resource *concepts.Resource, method *concepts.Method) map[string]interface{} {
+	message := map[string]interface{}{
+		"name": messageName(resource, method),
+	}
+	if method.Doc() != "" {
+		message["summary"] = method.Doc()
+	}
+	payload := g.eventPayload(method)
+	if payload != nil {
+		message["payload"] = payload
+	}
+	return message
+}
+
+// eventPayload looks for the parameter that carries the event body, conventionally named 'body',
+// and returns its schema reference. If the method doesn't have one, it returns nil.
+func (g *Generator) eventPayload(method *concepts.Method) map[string]interface{} {
+	for _, parameter := range method.Parameters() {
+		if parameter.Out() && parameter.Name().String() == "body" {
+			return g.schemas.Reference(parameter.Type())
+		}
+	}
+	return nil
+}
+
+// writeDocument writes the given document to the output directory, once as YAML and once as
+// JSON, using the name of the service and the version to build the file names.
+func (g *Generator) writeDocument(service *concepts.Service, version *concepts.Version, document map[string]interface{}) error {
+	dir := filepath.Join(g.output, service.Name().String())
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	yamlData, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+	yamlFile := filepath.Join(dir, fmt.Sprintf("%s.yaml", version.Name().String()))
+	err = ioutil.WriteFile(yamlFile, yamlData, 0644)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonFile := filepath.Join(dir, fmt.Sprintf("%s.json", version.Name().String()))
+	return ioutil.WriteFile(jsonFile, jsonData, 0644)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/reporter"
+)
+
+// kubernetesAnnotation is the name of the annotation that marks a struct type as the source of a
+// Kubernetes custom resource definition, for example:
+//
+//	@kubernetes(group="ocm.openshift.io", plural="clusters")
+//	struct Cluster {
+//		...
+//	}
+const kubernetesAnnotation = "kubernetes"
+
+// kubernetesSpec describes the parameters given to the 'kubernetes' annotation of a type.
+type kubernetesSpec struct {
+	group  string
+	plural string
+}
+
+// findKubernetesSpec looks for the 'kubernetes' annotation in the given type and, if found,
+// returns the group and plural that it specifies. The second return value indicates whether the
+// type opted in with a usable annotation, so that callers can tell apart a type that didn't opt
+// in at all from one that did but is missing a mandatory parameter; the latter is reported as an
+// error instead of silently producing a manifest with an empty 'group' or 'plural'.
+func findKubernetesSpec(reporter *reporter.Reporter, typ *concepts.Type) (spec *kubernetesSpec, ok bool) {
+	annotation := typ.Annotation(kubernetesAnnotation)
+	if annotation == nil {
+		return nil, false
+	}
+	group := annotation.Parameter("group")
+	plural := annotation.Parameter("plural")
+	if group == "" || plural == "" {
+		reporter.Errorf(
+			"Type '%s' has a 'kubernetes' annotation but doesn't specify both 'group' and "+
+				"'plural'",
+			typ.Name(),
+		)
+		return nil, false
+	}
+	spec = &kubernetesSpec{
+		group:  group,
+		plural: plural,
+	}
+	return spec, true
+}
+
+// computedAnnotation is the name of the annotation that marks an attribute as being calculated by
+// the server, so that the CRD generator places it under the 'status' section instead of 'spec'.
+const computedAnnotation = "computed"
+
+// isComputed returns true if the given attribute has been marked with the 'computed' annotation.
+func isComputed(attribute *concepts.Attribute) bool {
+	return attribute.Annotation(computedAnnotation) != nil
+}
@@ -0,0 +1,244 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crd contains the generator that produces Kubernetes CustomResourceDefinition manifests
+// for the struct types of the model that opt in via the 'kubernetes' annotation.
+//
+// NOTE: this generator isn't registered with the 'generate' command dispatch yet, because the
+// cmd package that owns that dispatch isn't part of this checkout. Whoever adds the 'generate
+// crd' subcommand should build it the same way the existing subcommands build their generator:
+// NewGenerator().Reporter(...).Model(...).Output(...).Build(), then call Run() on the result.
+package crd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/reporter"
+)
+
+// GeneratorBuilder is an object used to configure and build the CRD generator. Don't create
+// instances directly, use the NewGenerator function instead.
+type GeneratorBuilder struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+}
+
+// Generator generates CustomResourceDefinition manifests for the model. Don't create instances
+// directly, use the builder instead.
+type Generator struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+}
+
+// NewGenerator creates a new builder for CRD generators.
+func NewGenerator() *GeneratorBuilder {
+	return &GeneratorBuilder{}
+}
+
+// Reporter sets the object that will be used to report information about the generation process,
+// including errors.
+func (b *GeneratorBuilder) Reporter(value *reporter.Reporter) *GeneratorBuilder {
+	b.reporter = value
+	return b
+}
+
+// Model sets the model that will be used by the generator.
+func (b *GeneratorBuilder) Model(value *concepts.Model) *GeneratorBuilder {
+	b.model = value
+	return b
+}
+
+// Output sets the directory where the generated manifests will be written.
+func (b *GeneratorBuilder) Output(value string) *GeneratorBuilder {
+	b.output = value
+	return b
+}
+
+// Build checks the configuration stored in the builder and, if it is correct, creates a new CRD
+// generator using it.
+func (b *GeneratorBuilder) Build() (generator *Generator, err error) {
+	if b.reporter == nil {
+		err = fmt.Errorf("reporter is mandatory")
+		return
+	}
+	if b.model == nil {
+		err = fmt.Errorf("model is mandatory")
+		return
+	}
+	if b.output == "" {
+		err = fmt.Errorf("output is mandatory")
+		return
+	}
+	generator = &Generator{
+		reporter: b.reporter,
+		model:    b.model,
+		output:   b.output,
+	}
+	return
+}
+
+// Run executes the code generator.
+func (g *Generator) Run() error {
+	err := os.MkdirAll(g.output, 0755)
+	if err != nil {
+		return err
+	}
+
+	order := []kubernetesSpec{}
+	manifests := map[kubernetesSpec]map[string]interface{}{}
+	for _, service := range g.model.Services() {
+		for _, version := range service.Versions() {
+			for _, typ := range version.Types() {
+				if !typ.IsStruct() {
+					continue
+				}
+				spec, ok := findKubernetesSpec(g.reporter, typ)
+				if !ok {
+					continue
+				}
+				err = g.writeManifest(service, version, typ, spec)
+				if err != nil {
+					return err
+				}
+				key := *spec
+				if manifest, exists := manifests[key]; exists {
+					addVersion(manifest, g.generateVersion(version, typ))
+				} else {
+					manifests[key] = g.generateManifest(typ, spec, g.generateVersion(version, typ))
+					order = append(order, key)
+				}
+			}
+		}
+	}
+
+	combined := make([]interface{}, len(order))
+	for i, key := range order {
+		combined[i] = manifests[key]
+	}
+	return g.writeCombinedManifest(combined)
+}
+
+// generateVersion builds the 'spec.versions' entry of the CustomResourceDefinition manifest that
+// corresponds to the given model version of the given type.
+func (g *Generator) generateVersion(version *concepts.Version, typ *concepts.Type) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"spec":   specSchema(typ),
+			"status": statusSchema(typ),
+		},
+	}
+	if typ.Doc() != "" {
+		schema["description"] = typ.Doc()
+	}
+	return map[string]interface{}{
+		"name":    version.Name().String(),
+		"served":  true,
+		"storage": true,
+		"subresources": map[string]interface{}{
+			"status": map[string]interface{}{},
+		},
+		"schema": map[string]interface{}{
+			"openAPIV3Schema": schema,
+		},
+	}
+}
+
+// generateManifest builds the CustomResourceDefinition manifest for a type the first time its
+// 'kubernetes' annotation is seen, using crdVersion as its sole 'spec.versions' entry. A later
+// model version that shares the same group and plural is folded into this same manifest by
+// addVersion, instead of producing a second CustomResourceDefinition with a colliding
+// 'metadata.name'.
+func (g *Generator) generateManifest(
+	typ *concepts.Type, spec *kubernetesSpec, crdVersion map[string]interface{},
+) map[string]interface{} {
+	kind := typ.Name().String()
+	return map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]interface{}{
+			"name": fmt.Sprintf("%s.%s", spec.plural, spec.group),
+		},
+		"spec": map[string]interface{}{
+			"group": spec.group,
+			"scope": "Namespaced",
+			"names": map[string]interface{}{
+				"kind":     kind,
+				"plural":   spec.plural,
+				"singular": kind,
+			},
+			"versions": []interface{}{crdVersion},
+		},
+	}
+}
+
+// addVersion folds another model version's schema into an existing CustomResourceDefinition
+// manifest, demoting the versions already present so that only the newest one is marked as the
+// storage version, as required by the Kubernetes API.
+func addVersion(manifest map[string]interface{}, crdVersion map[string]interface{}) {
+	spec := manifest["spec"].(map[string]interface{})
+	versions := spec["versions"].([]interface{})
+	for _, existing := range versions {
+		existing.(map[string]interface{})["storage"] = false
+	}
+	spec["versions"] = append(versions, crdVersion)
+}
+
+// writeManifest writes the standalone, single-version manifest of a type to its own file,
+// namespaced by service and version so that the same type name used by more than one API version
+// doesn't overwrite a sibling file on disk.
+func (g *Generator) writeManifest(
+	service *concepts.Service, version *concepts.Version, typ *concepts.Type, spec *kubernetesSpec,
+) error {
+	manifest := g.generateManifest(typ, spec, g.generateVersion(version, typ))
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(g.output, service.Name().String(), version.Name().String())
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(dir, fmt.Sprintf("%s.yaml", typ.Name().String()))
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// writeCombinedManifest writes every generated manifest, one after another, into a single YAML
+// stream, so that the whole set of custom resource definitions can be applied with one command.
+func (g *Generator) writeCombinedManifest(manifests []interface{}) error {
+	buffer := []byte{}
+	for i, manifest := range manifests {
+		if i > 0 {
+			buffer = append(buffer, []byte("---\n")...)
+		}
+		data, err := yaml.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		buffer = append(buffer, data...)
+	}
+	file := filepath.Join(g.output, "combined.yaml")
+	return ioutil.WriteFile(file, buffer, 0644)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import "testing"
+
+func newVersion(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":    name,
+		"served":  true,
+		"storage": true,
+	}
+}
+
+func TestAddVersionAppendsAndDemotesStorage(t *testing.T) {
+	manifest := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"versions": []interface{}{newVersion("v1")},
+		},
+	}
+
+	addVersion(manifest, newVersion("v2"))
+
+	spec := manifest["spec"].(map[string]interface{})
+	versions := spec["versions"].([]interface{})
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+
+	v1 := versions[0].(map[string]interface{})
+	if v1["storage"] != false {
+		t.Errorf("expected the original version to be demoted, got storage=%v", v1["storage"])
+	}
+
+	v2 := versions[1].(map[string]interface{})
+	if v2["storage"] != true {
+		t.Errorf("expected the newly added version to be the storage version, got storage=%v", v2["storage"])
+	}
+	if v2["name"] != "v2" {
+		t.Errorf("expected the newly added version to be named 'v2', got %v", v2["name"])
+	}
+}
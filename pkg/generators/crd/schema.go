@@ -0,0 +1,185 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+)
+
+// schemaProperty builds the 'openAPIV3Schema' fragment that describes the given type, recursing
+// into struct, list and map types.
+func schemaProperty(typ *concepts.Type) map[string]interface{} {
+	switch {
+	case typ.IsScalar():
+		return scalarProperty(typ)
+	case typ.IsEnum():
+		return enumProperty(typ)
+	case typ.IsStruct():
+		return structProperty(typ)
+	case typ.IsList():
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schemaProperty(typ.Element()),
+		}
+	case typ.IsMap():
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": schemaProperty(typ.Element()),
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func scalarProperty(typ *concepts.Type) map[string]interface{} {
+	switch typ.Name().String() {
+	case "string":
+		return map[string]interface{}{
+			"type": "string",
+		}
+	case "boolean":
+		return map[string]interface{}{
+			"type": "boolean",
+		}
+	case "integer":
+		return map[string]interface{}{
+			"type":   "integer",
+			"format": "int32",
+		}
+	case "long":
+		return map[string]interface{}{
+			"type":   "integer",
+			"format": "int64",
+		}
+	case "float":
+		return map[string]interface{}{
+			"type":   "number",
+			"format": "float",
+		}
+	case "date":
+		return map[string]interface{}{
+			"type":   "string",
+			"format": "date-time",
+		}
+	case "interface":
+		return map[string]interface{}{
+			"x-kubernetes-preserve-unknown-fields": true,
+		}
+	default:
+		return map[string]interface{}{
+			"type": "string",
+		}
+	}
+}
+
+func enumProperty(typ *concepts.Type) map[string]interface{} {
+	values := make([]string, len(typ.Values()))
+	for i, value := range typ.Values() {
+		values[i] = value.Name().String()
+	}
+	return map[string]interface{}{
+		"type": "string",
+		"enum": values,
+	}
+}
+
+// structProperty builds the 'openAPIV3Schema' fragment for an embedded struct type. Unlike
+// specSchema and statusSchema, this doesn't split the attributes between 'spec' and 'status',
+// since that split only applies to the top level struct that a 'kubernetes' annotated type
+// exposes as a custom resource.
+func structProperty(typ *concepts.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	if typ.IsClass() {
+		properties["kind"] = map[string]interface{}{
+			"type": "string",
+		}
+		properties["id"] = map[string]interface{}{
+			"type": "string",
+		}
+		properties["href"] = map[string]interface{}{
+			"type": "string",
+		}
+	}
+	for _, attribute := range typ.Attributes() {
+		properties[attribute.Name().String()] = propertyWithDoc(attribute)
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// propertyWithDoc builds the schema fragment for the given attribute and adds a 'description'
+// field taken from its documentation, when present.
+func propertyWithDoc(attribute *concepts.Attribute) map[string]interface{} {
+	property := schemaProperty(attribute.Type())
+	if attribute.Doc() != "" {
+		property["description"] = attribute.Doc()
+	}
+	return property
+}
+
+// specSchema builds the 'spec' section of the custom resource schema, containing every attribute
+// of the given type that hasn't been marked with the 'computed' annotation, plus the list of
+// attributes that the model itself marks as mandatory.
+func specSchema(typ *concepts.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	for _, attribute := range typ.Attributes() {
+		if isComputed(attribute) {
+			continue
+		}
+		name := attribute.Name().String()
+		properties[name] = propertyWithDoc(attribute)
+		if attribute.Mandatory() {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// statusSchema builds the 'status' section of the custom resource schema. For class types it also
+// contains the 'id' and 'href' attributes that every class carries, plus every attribute that has
+// been marked with the 'computed' annotation.
+func statusSchema(typ *concepts.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	if typ.IsClass() {
+		properties["id"] = map[string]interface{}{
+			"type": "string",
+		}
+		properties["href"] = map[string]interface{}{
+			"type": "string",
+		}
+	}
+	for _, attribute := range typ.Attributes() {
+		if !isComputed(attribute) {
+			continue
+		}
+		properties[attribute.Name().String()] = propertyWithDoc(attribute)
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package examples contains the generator that produces example request and response payloads,
+// together with runnable 'curl' snippets, for every method of the model.
+//
+// Most of this package's logic takes *concepts.Type/*concepts.Method fixtures to exercise
+// properly; see pkg/generators/paths and pkg/generators/crd for the parts of this generator
+// series that could be covered with plain Go tests in this checkout.
+package examples
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/paths"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/rest"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/reporter"
+)
+
+// GeneratorBuilder is an object used to configure and build the examples generator. Don't create
+// instances directly, use the NewGenerator function instead.
+type GeneratorBuilder struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+}
+
+// Generator generates example payloads for the methods of the model. Don't create instances
+// directly, use the builder instead.
+type Generator struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+}
+
+// NewGenerator creates a new builder for examples generators.
+func NewGenerator() *GeneratorBuilder {
+	return &GeneratorBuilder{}
+}
+
+// Reporter sets the object that will be used to report information about the generation process,
+// including errors.
+func (b *GeneratorBuilder) Reporter(value *reporter.Reporter) *GeneratorBuilder {
+	b.reporter = value
+	return b
+}
+
+// Model sets the model that will be used by the generator.
+func (b *GeneratorBuilder) Model(value *concepts.Model) *GeneratorBuilder {
+	b.model = value
+	return b
+}
+
+// Output sets the directory where the generated examples will be written.
+func (b *GeneratorBuilder) Output(value string) *GeneratorBuilder {
+	b.output = value
+	return b
+}
+
+// Build checks the configuration stored in the builder and, if it is correct, creates a new
+// examples generator using it.
+func (b *GeneratorBuilder) Build() (generator *Generator, err error) {
+	if b.reporter == nil {
+		err = fmt.Errorf("reporter is mandatory")
+		return
+	}
+	if b.model == nil {
+		err = fmt.Errorf("model is mandatory")
+		return
+	}
+	if b.output == "" {
+		err = fmt.Errorf("output is mandatory")
+		return
+	}
+	generator = &Generator{
+		reporter: b.reporter,
+		model:    b.model,
+		output:   b.output,
+	}
+	return
+}
+
+// Run executes the code generator.
+func (g *Generator) Run() error {
+	for _, service := range g.model.Services() {
+		for _, version := range service.Versions() {
+			for _, resource := range version.Resources() {
+				for _, method := range resource.Methods() {
+					if method.IsStream() {
+						// Streaming methods don't have a one-shot request/response
+						// representation; they are described by the AsyncAPI generator
+						// instead.
+						continue
+					}
+					if rest.Verb(method) == "" {
+						g.reporter.Errorf(
+							"Don't know which HTTP verb corresponds to method '%s'",
+							method.Name(),
+						)
+						continue
+					}
+					err := g.generateExample(service, version, resource, method)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// generateExample writes the example payload and the 'curl' snippet that correspond to the given
+// method.
+func (g *Generator) generateExample(
+	service *concepts.Service, version *concepts.Version,
+	resource *concepts.Resource, method *concepts.Method,
+) error {
+	dir := filepath.Join(
+		g.output,
+		service.Name().String(),
+		version.Name().String(),
+		g.resourcePath(resource),
+	)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	example := g.generatePayload(resource, method)
+	data, err := json.MarshalIndent(example, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonFile := filepath.Join(dir, fmt.Sprintf("%s.json", method.Name().String()))
+	err = ioutil.WriteFile(jsonFile, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	snippet := g.generateCurl(resource, method, example)
+	curlFile := filepath.Join(dir, fmt.Sprintf("%s.sh", method.Name().String()))
+	return ioutil.WriteFile(curlFile, []byte(snippet), 0644)
+}
+
+// payload groups the request and response bodies synthesized for a method, together with the
+// values used for its path parameters.
+type payload struct {
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Request    interface{}            `json:"request,omitempty"`
+	Response   interface{}            `json:"response,omitempty"`
+}
+
+// generatePayload synthesizes the example request and response bodies for the given method, as
+// well as example values for its path parameters. The path parameter names are derived from the
+// resource's chain of locators, via the shared paths package, so that a resource nested behind
+// more than one variable locator gets one distinct value per parameter instead of a single
+// repeated 'id'.
+func (g *Generator) generatePayload(resource *concepts.Resource, method *concepts.Method) *payload {
+	values := newValueGenerator()
+	example := &payload{
+		Parameters: map[string]interface{}{},
+	}
+	_, parameters := paths.Walk(resource)
+	for _, name := range parameters {
+		example.Parameters[name] = "123"
+	}
+	for _, parameter := range method.Parameters() {
+		name := parameter.Name().String()
+		switch {
+		case parameter.In() && name == "body":
+			example.Request = values.Value(parameter.Type())
+		case parameter.In():
+			example.Parameters[name] = values.NamedValue(name, parameter.Type())
+		case parameter.Out() && name == "body":
+			example.Response = values.Value(parameter.Type())
+		}
+	}
+	return example
+}
+
+// generateCurl builds a runnable 'curl' snippet that exercises the given method using the values
+// from the generated example. The caller only invokes this for methods that Run has already
+// confirmed have a known HTTP verb.
+func (g *Generator) generateCurl(resource *concepts.Resource, method *concepts.Method, example *payload) string {
+	verb := rest.Verb(method)
+	path := "/api" + g.resolvedPath(resource, example)
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "#!/bin/sh\n")
+	fmt.Fprintf(&builder, "curl -X %s \\\n", strings.ToUpper(verb))
+	fmt.Fprintf(&builder, "  -H \"Authorization: Bearer ${TOKEN}\" \\\n")
+	fmt.Fprintf(&builder, "  -H 'Content-Type: application/json' \\\n")
+	if example.Request != nil {
+		body, err := json.Marshal(example.Request)
+		if err == nil {
+			fmt.Fprintf(&builder, "  -d '%s' \\\n", string(body))
+		}
+	}
+	fmt.Fprintf(&builder, "  \"${OCM_URL}%s\"\n", path)
+	return builder.String()
+}
+
+// resourcePath returns the path of the resource expressed using file system friendly segments,
+// stripping the '{' and '}' that mark a path parameter so that, for example, the locator that
+// leads to a single cluster contributes a 'clusterId' directory instead of a '{clusterId}' one.
+func (g *Generator) resourcePath(resource *concepts.Resource) string {
+	segments, _ := paths.Walk(resource)
+	names := make([]string, len(segments))
+	for i, segment := range segments {
+		names[i] = strings.Trim(segment, "{}")
+	}
+	return filepath.Join(names...)
+}
+
+// resolvedPath returns the URL path of the resource with every path parameter replaced by the
+// corresponding value from the given example, so that the generated 'curl' snippet is runnable
+// as is instead of containing literal '{...}' placeholders.
+func (g *Generator) resolvedPath(resource *concepts.Resource, example *payload) string {
+	segments, _ := paths.Walk(resource)
+	path := paths.Join(segments)
+	for _, name := range paths.ParametersOf(path) {
+		path = strings.Replace(path, fmt.Sprintf("{%s}", name), fmt.Sprintf("%v", example.Parameters[name]), 1)
+	}
+	return path
+}
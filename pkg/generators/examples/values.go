@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package examples
+
+import (
+	"time"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+)
+
+// valueGenerator synthesizes example values for model types. A single instance is reused for a
+// whole example so that the set of types currently being expanded can be tracked, which is what
+// allows recursive types to be broken without looping forever.
+type valueGenerator struct {
+	visited map[*concepts.Type]bool
+}
+
+// newValueGenerator creates a new, empty value generator.
+func newValueGenerator() *valueGenerator {
+	return &valueGenerator{
+		visited: map[*concepts.Type]bool{},
+	}
+}
+
+// Value returns an example value for the given type, recursively synthesizing the values of its
+// attributes, elements or entries as needed. If the type is already being expanded by an
+// enclosing call, for example because of a self referencing struct, it returns nil instead of
+// recursing forever.
+func (g *valueGenerator) Value(typ *concepts.Type) interface{} {
+	switch {
+	case typ.IsScalar():
+		return g.scalarValue(typ)
+	case typ.IsEnum():
+		return g.enumValue(typ)
+	case typ.IsStruct():
+		return g.structValue(typ)
+	case typ.IsList():
+		return g.listValue(typ)
+	case typ.IsMap():
+		return g.mapValue(typ)
+	default:
+		return nil
+	}
+}
+
+// NamedValue returns an example value for the given attribute, using its name as the seed for
+// string placeholders so that the generated examples read naturally, for example the 'name'
+// attribute of a cluster produces the string 'name' itself.
+func (g *valueGenerator) NamedValue(name string, typ *concepts.Type) interface{} {
+	if typ.IsScalar() && typ.Name().String() == "string" {
+		return name
+	}
+	return g.Value(typ)
+}
+
+func (g *valueGenerator) scalarValue(typ *concepts.Type) interface{} {
+	switch typ.Name().String() {
+	case "string":
+		return typ.Name().String()
+	case "boolean":
+		return true
+	case "integer", "long":
+		return 42
+	case "float":
+		return 42.0
+	case "date":
+		// A fixed instant, rather than time.Now(), keeps the generated fixtures stable across
+		// runs so that regenerating the examples doesn't produce a diff on every invocation.
+		return time.Unix(0, 0).UTC().Format(time.RFC3339)
+	default:
+		return nil
+	}
+}
+
+func (g *valueGenerator) enumValue(typ *concepts.Type) interface{} {
+	values := typ.Values()
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0].Name().String()
+}
+
+func (g *valueGenerator) structValue(typ *concepts.Type) interface{} {
+	if g.visited[typ] {
+		return nil
+	}
+	g.visited[typ] = true
+	defer delete(g.visited, typ)
+
+	object := map[string]interface{}{}
+	if typ.IsClass() {
+		object["kind"] = typ.Name().String()
+		object["id"] = "123"
+		object["href"] = "/api/" + typ.Name().String()
+	}
+	for _, attribute := range typ.Attributes() {
+		name := attribute.Name().String()
+		object[name] = g.NamedValue(name, attribute.Type())
+	}
+	return object
+}
+
+func (g *valueGenerator) listValue(typ *concepts.Type) interface{} {
+	element := typ.Element()
+	return []interface{}{
+		g.Value(element),
+		g.Value(element),
+	}
+}
+
+func (g *valueGenerator) mapValue(typ *concepts.Type) interface{} {
+	element := typ.Element()
+	return map[string]interface{}{
+		"key": g.Value(element),
+	}
+}
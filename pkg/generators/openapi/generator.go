@@ -0,0 +1,324 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package openapi contains the generator that produces an OpenAPI 3.0 document, in both YAML and
+// JSON, for each version of the model.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/rest"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/schema"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/reporter"
+)
+
+// GeneratorBuilder is an object used to configure and build the OpenAPI generator. Don't create
+// instances directly, use the NewGenerator function instead.
+type GeneratorBuilder struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+}
+
+// Generator generates OpenAPI 3.0 documents for the model. Don't create instances directly, use
+// the builder instead.
+type Generator struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+	paths    *PathsCalculator
+	schemas  *schema.Calculator
+}
+
+// NewGenerator creates a new builder for OpenAPI generators.
+func NewGenerator() *GeneratorBuilder {
+	return &GeneratorBuilder{}
+}
+
+// Reporter sets the object that will be used to report information about the generation process,
+// including errors.
+func (b *GeneratorBuilder) Reporter(value *reporter.Reporter) *GeneratorBuilder {
+	b.reporter = value
+	return b
+}
+
+// Model sets the model that will be used by the generator.
+func (b *GeneratorBuilder) Model(value *concepts.Model) *GeneratorBuilder {
+	b.model = value
+	return b
+}
+
+// Output sets the directory where the generated documents will be written.
+func (b *GeneratorBuilder) Output(value string) *GeneratorBuilder {
+	b.output = value
+	return b
+}
+
+// Build checks the configuration stored in the builder and, if it is correct, creates a new
+// OpenAPI generator using it.
+func (b *GeneratorBuilder) Build() (generator *Generator, err error) {
+	if b.reporter == nil {
+		err = fmt.Errorf("reporter is mandatory")
+		return
+	}
+	if b.model == nil {
+		err = fmt.Errorf("model is mandatory")
+		return
+	}
+	if b.output == "" {
+		err = fmt.Errorf("output is mandatory")
+		return
+	}
+	generator = &Generator{
+		reporter: b.reporter,
+		model:    b.model,
+		output:   b.output,
+		paths:    NewPathsCalculator(),
+		schemas:  schema.NewCalculator(),
+	}
+	return
+}
+
+// Run executes the code generator.
+func (g *Generator) Run() error {
+	for _, service := range g.model.Services() {
+		for _, version := range service.Versions() {
+			document := g.generateDocument(service, version)
+			err := g.writeDocument(service, version, document)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// generateDocument builds the OpenAPI document that corresponds to the given version, as a tree
+// of maps and slices ready to be marshalled to YAML or JSON.
+func (g *Generator) generateDocument(service *concepts.Service, version *concepts.Version) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	for _, typ := range version.Types() {
+		switch {
+		case typ.IsStruct(), typ.IsEnum():
+			schemas[g.schemas.Name(typ)] = g.schemas.Schema(typ)
+		}
+	}
+	paths := map[string]interface{}{}
+	for _, resource := range version.Resources() {
+		generated := g.generateOperations(resource)
+		if len(generated) == 0 {
+			// None of the methods of this resource produced an operation: it has no
+			// methods, or they are all streaming methods (described by the AsyncAPI
+			// generator instead) or have no known HTTP verb. It doesn't contribute a
+			// path item here.
+			continue
+		}
+		path := g.paths.Path(resource)
+		operations, ok := paths[path].(map[string]interface{})
+		if !ok {
+			operations = map[string]interface{}{}
+			paths[path] = operations
+		}
+		for verb, operation := range generated {
+			operations[verb] = operation
+		}
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   fmt.Sprintf("%s %s", service.Name().String(), version.Name().String()),
+			"version": version.Name().String(),
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+// generateOperations builds the map of HTTP verbs to OpenAPI operation objects for the methods of
+// the given resource.
+func (g *Generator) generateOperations(resource *concepts.Resource) map[string]interface{} {
+	pathParameters := g.paths.Parameters(resource)
+	operations := map[string]interface{}{}
+	for _, method := range resource.Methods() {
+		if method.IsStream() {
+			// Streaming methods don't have a synchronous HTTP representation; they are
+			// described by the AsyncAPI generator instead.
+			continue
+		}
+		verb := rest.Verb(method)
+		if verb == "" {
+			g.reporter.Errorf(
+				"Don't know which HTTP verb corresponds to method '%s'",
+				method.Name(),
+			)
+			continue
+		}
+		operation := map[string]interface{}{
+			"operationId": method.Name().String(),
+		}
+		if method.Doc() != "" {
+			operation["description"] = method.Doc()
+		}
+		parameters := g.generateParameters(pathParameters, method)
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+		requestBody := g.generateRequestBody(method)
+		if requestBody != nil {
+			operation["requestBody"] = requestBody
+		}
+		operation["responses"] = map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Success",
+				"content":     g.generateResponseContent(method),
+			},
+		}
+		operations[verb] = operation
+	}
+	return operations
+}
+
+// generateParameters builds the 'parameters' array of an operation: one entry for every path
+// parameter of the resource, so that the document stays valid (every path template parameter
+// must be declared), plus one query parameter for every non-body input parameter of the method,
+// including the paging parameters ('page' and 'size') that 'list' methods take.
+func (g *Generator) generateParameters(pathParameters []string, method *concepts.Method) []interface{} {
+	names := map[string]bool{}
+	parameters := make([]interface{}, 0, len(pathParameters))
+	for _, name := range pathParameters {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema": map[string]interface{}{
+				"type": "string",
+			},
+		})
+		names[name] = true
+	}
+	for _, parameter := range method.Parameters() {
+		if !parameter.In() || parameter.Name().String() == "body" {
+			continue
+		}
+		name := parameter.Name().String()
+		query := map[string]interface{}{
+			"name":   name,
+			"in":     "query",
+			"schema": g.schemas.Reference(parameter.Type()),
+		}
+		if parameter.Doc() != "" {
+			query["description"] = parameter.Doc()
+		}
+		parameters = append(parameters, query)
+		names[name] = true
+	}
+	if method.Name().String() == "list" {
+		if !names["page"] {
+			parameters = append(parameters, map[string]interface{}{
+				"name": "page",
+				"in":   "query",
+				"description": "Index of the requested page, where one corresponds to the " +
+					"first page.",
+				"schema": map[string]interface{}{
+					"type":    "integer",
+					"default": 1,
+				},
+			})
+		}
+		if !names["size"] {
+			parameters = append(parameters, map[string]interface{}{
+				"name":        "size",
+				"in":          "query",
+				"description": "Maximum number of items to be returned by the request.",
+				"schema": map[string]interface{}{
+					"type":    "integer",
+					"default": 100,
+				},
+			})
+		}
+	}
+	return parameters
+}
+
+// generateRequestBody builds the 'requestBody' of an operation, referencing the schema of the
+// method's 'body' input parameter, if it has one.
+func (g *Generator) generateRequestBody(method *concepts.Method) map[string]interface{} {
+	for _, parameter := range method.Parameters() {
+		if parameter.In() && parameter.Name().String() == "body" {
+			return map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": g.schemas.Reference(parameter.Type()),
+					},
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// generateResponseContent builds the 'content' of the success response of an operation,
+// referencing the schema of the method's 'body' output parameter, if it has one.
+func (g *Generator) generateResponseContent(method *concepts.Method) map[string]interface{} {
+	for _, parameter := range method.Parameters() {
+		if parameter.Out() && parameter.Name().String() == "body" {
+			return map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": g.schemas.Reference(parameter.Type()),
+				},
+			}
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// writeDocument writes the given document to the output directory, once as YAML and once as
+// JSON, using the name of the service and the version to build the file names.
+func (g *Generator) writeDocument(service *concepts.Service, version *concepts.Version, document map[string]interface{}) error {
+	dir := filepath.Join(g.output, service.Name().String())
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	yamlData, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+	yamlFile := filepath.Join(dir, fmt.Sprintf("%s.yaml", version.Name().String()))
+	err = ioutil.WriteFile(yamlFile, yamlData, 0644)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonFile := filepath.Join(dir, fmt.Sprintf("%s.json", version.Name().String()))
+	return ioutil.WriteFile(jsonFile, jsonData, 0644)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/paths"
+)
+
+// PathsCalculator is an object used to reconstruct the REST path of a resource by walking the
+// chain of locators that lead to it. Don't create instances directly, use the
+// NewPathsCalculator function instead.
+type PathsCalculator struct {
+}
+
+// NewPathsCalculator creates a new paths calculator.
+func NewPathsCalculator() *PathsCalculator {
+	return &PathsCalculator{}
+}
+
+// Path returns the path of the given resource, relative to the root of the version that owns it.
+func (c *PathsCalculator) Path(resource *concepts.Resource) string {
+	segments, _ := paths.Walk(resource)
+	return paths.Join(segments)
+}
+
+// Parameters returns, in the order they appear in the path, the names of the path parameters
+// referenced by the path of the given resource. This is exactly the set of names that must be
+// declared in the 'parameters' array of every operation of the path, since every template
+// parameter in an OpenAPI path must have a matching declaration.
+func (c *PathsCalculator) Parameters(resource *concepts.Resource) []string {
+	_, parameters := paths.Walk(resource)
+	return parameters
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package paths contains the logic used to reconstruct the address of a resource by walking the
+// chain of locators that lead to it. It is shared by every generator that needs to describe that
+// address using the same syntax, for example the OpenAPI generator, which uses it to build REST
+// paths, and the AsyncAPI generator, which uses it to build channel addresses.
+package paths
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/schema"
+)
+
+// Walk calculates the list of path segments and the list of path parameter names that lead from
+// the root resource of the version down to the given resource, by searching the tree of locators
+// for the chain that ends at it. Constant locators contribute a literal segment, and each variable
+// locator contributes a '{<resource>Id}' parameter segment. Deriving the parameter name from the
+// resource that the locator targets, instead of hardcoding it, is what keeps parameter names
+// distinct when a path has more than one variable locator, e.g.
+// '/clusters/{clusterId}/groups/{groupId}'.
+func Walk(resource *concepts.Resource) (segments []string, parameters []string) {
+	segments, parameters, _ = search(resource.Owner().Root(), resource)
+	return
+}
+
+// search looks for the given target resource inside the tree rooted at current, returning the
+// segments and parameters of the chain of locators that leads to it, and whether it was found.
+func search(current *concepts.Resource, target *concepts.Resource) (segments []string, parameters []string, found bool) {
+	if current == target {
+		return []string{}, []string{}, true
+	}
+	for _, locator := range current.Locators() {
+		var segment, parameter string
+		if locator.Variable() {
+			parameter = ParameterName(locator)
+			segment = fmt.Sprintf("{%s}", parameter)
+		} else {
+			segment = locator.Name().String()
+		}
+		childSegments, childParameters, ok := search(locator.Target(), target)
+		if !ok {
+			continue
+		}
+		segments = append([]string{segment}, childSegments...)
+		if parameter != "" {
+			parameters = append([]string{parameter}, childParameters...)
+		} else {
+			parameters = childParameters
+		}
+		return segments, parameters, true
+	}
+	return nil, nil, false
+}
+
+// Join joins the given path segments into an absolute path, for example the segments 'clusters'
+// and '{clusterId}' are joined into '/clusters/{clusterId}'.
+func Join(segments []string) string {
+	return "/" + strings.Join(segments, "/")
+}
+
+// ParameterName returns the name that should be used for the path parameter that corresponds to
+// the given variable locator, derived from the name of the resource it gives access to, for
+// example the locator that gives access to a single cluster contributes a 'clusterId' parameter.
+func ParameterName(locator *concepts.Locator) string {
+	return fmt.Sprintf("%sId", schema.PropertyName(locator.Target().Name()))
+}
+
+// ParametersOf returns the names of the path parameters referenced by the given path, in the
+// order in which they appear.
+func ParametersOf(path string) []string {
+	parameters := []string{}
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			parameters = append(parameters, strings.Trim(segment, "{}"))
+		}
+	}
+	return parameters
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paths
+
+import "testing"
+
+func TestJoin(t *testing.T) {
+	cases := []struct {
+		segments []string
+		expected string
+	}{
+		{[]string{}, "/"},
+		{[]string{"clusters"}, "/clusters"},
+		{[]string{"clusters", "{clusterId}", "groups"}, "/clusters/{clusterId}/groups"},
+	}
+	for _, c := range cases {
+		actual := Join(c.segments)
+		if actual != c.expected {
+			t.Errorf("Join(%v) = %q, expected %q", c.segments, actual, c.expected)
+		}
+	}
+}
+
+func TestParametersOf(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected []string
+	}{
+		{"/clusters", []string{}},
+		{"/clusters/{clusterId}", []string{"clusterId"}},
+		{
+			"/clusters/{clusterId}/groups/{groupId}",
+			[]string{"clusterId", "groupId"},
+		},
+	}
+	for _, c := range cases {
+		actual := ParametersOf(c.path)
+		if len(actual) != len(c.expected) {
+			t.Errorf("ParametersOf(%q) = %v, expected %v", c.path, actual, c.expected)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != c.expected[i] {
+				t.Errorf("ParametersOf(%q) = %v, expected %v", c.path, actual, c.expected)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rest contains small helpers shared by the generators that describe the synchronous,
+// request/response side of the model, such as the OpenAPI and examples generators.
+package rest
+
+import (
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+)
+
+// Verb returns the HTTP verb that corresponds to the given method, according to its name, for
+// example the 'add' method of a resource is exposed as a 'POST'. It returns an empty string if
+// the method doesn't follow one of the conventional names, so that callers can decide how to
+// report the problem.
+func Verb(method *concepts.Method) string {
+	switch method.Name().String() {
+	case "add":
+		return "post"
+	case "get", "list":
+		return "get"
+	case "update":
+		return "patch"
+	case "delete":
+		return "delete"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema contains the logic used to translate model types into JSON Schema fragments.
+// It started as part of the OpenAPI generator, and was extracted so that the AsyncAPI generator
+// could reuse it and produce identical 'components/schemas' entries for the types that both
+// specifications share.
+package schema
+
+import (
+	"fmt"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+)
+
+// Calculator is an object used to calculate the JSON Schema fragment that corresponds to a model
+// type. Don't create instances directly, use the NewCalculator function instead.
+type Calculator struct {
+}
+
+// NewCalculator creates a new schema calculator.
+func NewCalculator() *Calculator {
+	return &Calculator{}
+}
+
+// Name returns the name that will be used to identify the given type inside the
+// 'components/schemas' section of the document.
+func (c *Calculator) Name(typ *concepts.Type) string {
+	return Name(typ.Name())
+}
+
+// Reference returns the schema fragment that should be used to reference the given type from
+// another schema. For scalar types it returns an inline primitive schema, for struct and enum
+// types it returns a '$ref' that points to the 'components/schemas' section, for list types it
+// returns an 'array' schema whose 'items' is the reference of the element type, and for map types
+// it returns an 'object' schema with 'additionalProperties' set to the reference of the value
+// type.
+func (c *Calculator) Reference(typ *concepts.Type) map[string]interface{} {
+	switch {
+	case typ.IsScalar():
+		return c.scalarSchema(typ)
+	case typ.IsEnum(), typ.IsStruct():
+		return map[string]interface{}{
+			"$ref": fmt.Sprintf("#/components/schemas/%s", c.Name(typ)),
+		}
+	case typ.IsList():
+		return map[string]interface{}{
+			"type":  "array",
+			"items": c.Reference(typ.Element()),
+		}
+	case typ.IsMap():
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": c.Reference(typ.Element()),
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// scalarSchema returns the primitive JSON Schema fragment that corresponds to the given scalar
+// type.
+func (c *Calculator) scalarSchema(typ *concepts.Type) map[string]interface{} {
+	switch typ.Name().String() {
+	case "string":
+		return map[string]interface{}{
+			"type": "string",
+		}
+	case "boolean":
+		return map[string]interface{}{
+			"type": "boolean",
+		}
+	case "integer":
+		return map[string]interface{}{
+			"type":   "integer",
+			"format": "int32",
+		}
+	case "long":
+		return map[string]interface{}{
+			"type":   "integer",
+			"format": "int64",
+		}
+	case "float":
+		return map[string]interface{}{
+			"type":   "number",
+			"format": "float",
+		}
+	case "date":
+		return map[string]interface{}{
+			"type":   "string",
+			"format": "date-time",
+		}
+	case "interface":
+		return map[string]interface{}{}
+	default:
+		return map[string]interface{}{
+			"type": "string",
+		}
+	}
+}
+
+// Schema returns the full schema definition for the given struct or enum type, intended to be
+// stored under 'components/schemas'. For class types the 'kind', 'id' and 'href' attributes are
+// added automatically; the paging parameters of list wrappers aren't included here, as those
+// belong to the operation that returns the list instead of to the schema of the item.
+func (c *Calculator) Schema(typ *concepts.Type) map[string]interface{} {
+	switch {
+	case typ.IsEnum():
+		return c.enumSchema(typ)
+	case typ.IsStruct():
+		return c.structSchema(typ)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func (c *Calculator) enumSchema(typ *concepts.Type) map[string]interface{} {
+	values := make([]string, len(typ.Values()))
+	for i, value := range typ.Values() {
+		values[i] = value.Name().String()
+	}
+	schema := map[string]interface{}{
+		"type": "string",
+		"enum": values,
+	}
+	if typ.Doc() != "" {
+		schema["description"] = typ.Doc()
+	}
+	return schema
+}
+
+func (c *Calculator) structSchema(typ *concepts.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	if typ.IsClass() {
+		properties["kind"] = map[string]interface{}{
+			"type": "string",
+		}
+		properties["id"] = map[string]interface{}{
+			"type": "string",
+		}
+		properties["href"] = map[string]interface{}{
+			"type": "string",
+		}
+		required = append(required, "kind", "id")
+	}
+	for _, attribute := range typ.Attributes() {
+		name := attribute.Name().String()
+		property := c.Reference(attribute.Type())
+		if attribute.Doc() != "" {
+			property["description"] = attribute.Doc()
+		}
+		properties[name] = property
+	}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if typ.Doc() != "" {
+		schema["description"] = typ.Doc()
+	}
+	return schema
+}
@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"strings"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/names"
+)
+
+// Name converts a model name into the upper camel case form used to identify schemas, for
+// example 'cluster_ingress' becomes 'ClusterIngress'. It is exported so that other generators
+// that need to stay consistent with the identifiers used here, such as the AsyncAPI and
+// TypeScript generators, can reuse it instead of reimplementing the same casing rules.
+func Name(name *names.Name) string {
+	return casedName(name, true)
+}
+
+// PropertyName converts a model name into the lower camel case form used for JSON property
+// names, for example 'cluster_ingress' becomes 'clusterIngress'. Exported for the same reason as
+// Name.
+func PropertyName(name *names.Name) string {
+	return casedName(name, false)
+}
+
+// casedName splits the textual representation of the given name on the characters that the
+// model uses to separate words and joins them back together using camel case, capitalizing the
+// first word only when upper is true.
+func casedName(name *names.Name, upper bool) string {
+	words := strings.FieldsFunc(name.String(), func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	var builder strings.Builder
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		if i == 0 && !upper {
+			builder.WriteString(strings.ToLower(word))
+			continue
+		}
+		builder.WriteString(strings.ToUpper(word[:1]))
+		builder.WriteString(strings.ToLower(word[1:]))
+	}
+	return builder.String()
+}
@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package typescript complements the JavaScript generator, producing a '.d.ts' declaration file
+// for every struct and enum type of the model, and a fluent '<Object>Builder' class for every
+// struct type.
+//
+// The name-casing and type-reference logic here takes *concepts.Type/*concepts.Attribute
+// fixtures to exercise properly; see pkg/generators/paths and pkg/generators/crd for the parts
+// of this generator series that could be covered with plain Go tests in this checkout.
+package typescript
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/reporter"
+)
+
+// GeneratorBuilder is an object used to configure and build the TypeScript generator. Don't
+// create instances directly, use the NewGenerator function instead.
+type GeneratorBuilder struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+}
+
+// Generator generates TypeScript declarations and builders for the model types. Don't create
+// instances directly, use the builder instead.
+type Generator struct {
+	reporter *reporter.Reporter
+	model    *concepts.Model
+	output   string
+}
+
+// NewGenerator creates a new builder for TypeScript generators.
+func NewGenerator() *GeneratorBuilder {
+	return &GeneratorBuilder{}
+}
+
+// Reporter sets the object that will be used to report information about the generation process,
+// including errors.
+func (b *GeneratorBuilder) Reporter(value *reporter.Reporter) *GeneratorBuilder {
+	b.reporter = value
+	return b
+}
+
+// Model sets the model that will be used by the generator.
+func (b *GeneratorBuilder) Model(value *concepts.Model) *GeneratorBuilder {
+	b.model = value
+	return b
+}
+
+// Output sets the directory where the generated files will be written.
+func (b *GeneratorBuilder) Output(value string) *GeneratorBuilder {
+	b.output = value
+	return b
+}
+
+// Build checks the configuration stored in the builder and, if it is correct, creates a new
+// TypeScript generator using it.
+func (b *GeneratorBuilder) Build() (generator *Generator, err error) {
+	if b.reporter == nil {
+		err = fmt.Errorf("reporter is mandatory")
+		return
+	}
+	if b.model == nil {
+		err = fmt.Errorf("model is mandatory")
+		return
+	}
+	if b.output == "" {
+		err = fmt.Errorf("output is mandatory")
+		return
+	}
+	generator = &Generator{
+		reporter: b.reporter,
+		model:    b.model,
+		output:   b.output,
+	}
+	return
+}
+
+// Run executes the code generator.
+func (g *Generator) Run() error {
+	err := os.MkdirAll(g.output, 0755)
+	if err != nil {
+		return err
+	}
+	for _, service := range g.model.Services() {
+		for _, version := range service.Versions() {
+			for _, typ := range version.Types() {
+				switch {
+				case typ.IsEnum():
+					err = g.generateEnumDeclaration(typ)
+				case typ.IsStruct():
+					err = g.generateStructDeclaration(typ)
+					if err == nil {
+						err = g.generateBuilder(typ)
+					}
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+var declarationFuncs = template.FuncMap{
+	"objectName":  objectName,
+	"listName":    listName,
+	"builderName": builderName,
+	"fieldName":   fieldName,
+	"getterName":  getterName,
+	"setterName":  setterName,
+	"tsType":      NewTypesCalculator().TypeScriptReference,
+}
+
+var structDeclarationTemplate = template.Must(template.New("struct").Funcs(declarationFuncs).Parse(`
+{{ $objectName := objectName .Type }}
+{{ $listName := listName .Type }}
+
+// {{ $objectName }} represents the values of the '{{ .Type.Name }}' type.
+export interface {{ $objectName }} {
+{{ if .Type.IsClass }}
+	kind?: string;
+	id?: string;
+	href?: string;
+{{ end }}
+{{ range .Type.Attributes }}
+	{{ fieldName . }}?: {{ tsType .Type }};
+{{ end }}
+}
+
+// {{ $listName }} is a list of values of the '{{ .Type.Name }}' type.
+export interface {{ $listName }} {
+	kind?: string;
+	href?: string;
+	items: {{ $objectName }}[];
+}
+`))
+
+var enumDeclarationTemplate = template.Must(template.New("enum").Funcs(declarationFuncs).Parse(`
+{{ $objectName := objectName .Type }}
+
+// {{ $objectName }} represents the values of the '{{ .Type.Name }}' enumerated type.
+export type {{ $objectName }} =
+{{ range $i, $value := .Type.Values }}
+	{{ if $i }}| {{ else }}  {{ end }}'{{ $value.Name }}'
+{{ end }};
+`))
+
+var builderTemplate = template.Must(template.New("builder").Funcs(declarationFuncs).Parse(`
+{{ $objectName := objectName .Type }}
+{{ $builderName := builderName .Type }}
+
+// {{ $builderName }} is used to create instances of {{ $objectName }} with a fluent API.
+export class {{ $builderName }} {
+	private data: {{ $objectName }} = {};
+
+{{ range .Type.Attributes }}
+	// {{ setterName . }} sets the value of the '{{ .Name }}' attribute.
+	set{{ getterName . }}(value: {{ tsType .Type }}): {{ $builderName }} {
+		this.data.{{ fieldName . }} = value;
+		return this;
+	}
+{{ end }}
+
+	// build returns the {{ $objectName }} assembled so far.
+	build(): {{ $objectName }} {
+		return this.data;
+	}
+
+	// toJSON returns the plain object assembled so far, so that JSON.stringify(builder) and
+	// JSON.parse(...) round trip through the same {{ $objectName }} shape that 'from' expects.
+	toJSON(): {{ $objectName }} {
+		return this.data;
+	}
+
+	// from creates a new {{ $builderName }} seeded with the given JSON object.
+	static from(json: {{ $objectName }}): {{ $builderName }} {
+		const builder = new {{ $builderName }}();
+		builder.data = { ...json };
+		return builder;
+	}
+}
+`))
+
+func (g *Generator) generateStructDeclaration(typ *concepts.Type) error {
+	return g.render(structDeclarationTemplate, g.declarationFile(typ), typ)
+}
+
+func (g *Generator) generateEnumDeclaration(typ *concepts.Type) error {
+	return g.render(enumDeclarationTemplate, g.declarationFile(typ), typ)
+}
+
+func (g *Generator) generateBuilder(typ *concepts.Type) error {
+	return g.render(builderTemplate, g.builderFile(typ), typ)
+}
+
+func (g *Generator) render(tmpl *template.Template, file string, typ *concepts.Type) error {
+	var buffer bytes.Buffer
+	err := tmpl.Execute(&buffer, map[string]interface{}{
+		"Type": typ,
+	})
+	if err != nil {
+		g.reporter.Errorf("Can't generate '%s': %v", file, err)
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(g.output, file), buffer.Bytes(), 0644)
+}
+
+func (g *Generator) declarationFile(typ *concepts.Type) string {
+	return fmt.Sprintf("%s.d.ts", objectName(typ))
+}
+
+func (g *Generator) builderFile(typ *concepts.Type) string {
+	return fmt.Sprintf("%s.ts", builderName(typ))
+}
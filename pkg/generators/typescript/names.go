@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package typescript
+
+import (
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+	"github.com/openshift-online/ocm-api-metamodel/pkg/generators/schema"
+)
+
+// objectName returns the name of the interface and class generated for the given type, in upper
+// camel case, for example 'cluster' becomes 'Cluster'.
+func objectName(typ *concepts.Type) string {
+	return schema.Name(typ.Name())
+}
+
+// listName returns the name of the interface generated for the list of the given type, for
+// example 'cluster' becomes 'ClusterList'.
+func listName(typ *concepts.Type) string {
+	return objectName(typ) + "List"
+}
+
+// builderName returns the name of the builder class generated for the given type, for example
+// 'cluster' becomes 'ClusterBuilder'.
+func builderName(typ *concepts.Type) string {
+	return objectName(typ) + "Builder"
+}
+
+// fieldName returns the name of the property that holds the value of the given attribute, both in
+// the generated interface and in the builder's private 'data' object, using the model's native
+// attribute name so that it matches the JSON property the rest of the generators emit for the
+// same attribute.
+func fieldName(attribute *concepts.Attribute) string {
+	return attribute.Name().String()
+}
+
+// getterName returns the name of the given attribute in upper camel case, for example the 'name'
+// attribute of a cluster produces 'Name'. The builder template uses it to form the name of the
+// setter; no getter method is generated.
+func getterName(attribute *concepts.Attribute) string {
+	return schema.Name(attribute.Name())
+}
+
+// setterName returns the name of the fluent setter method generated for the given attribute in
+// the builder class, for example the 'name' attribute produces a 'setName' method.
+func setterName(attribute *concepts.Attribute) string {
+	return "set" + schema.Name(attribute.Name())
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package typescript
+
+import (
+	"fmt"
+
+	"github.com/openshift-online/ocm-api-metamodel/pkg/concepts"
+)
+
+// TypesCalculator calculates the TypeScript type expression that corresponds to a model type.
+// It plays the same role as the 'TypesCalculator' of the JavaScript generator, but instead of
+// deciding between value, nullable and list wrappers it produces a textual TypeScript type, so
+// that the declaration and builder generators can stay symmetric with the existing 'getterType'
+// and 'fieldType' logic. Don't create instances directly, use the NewTypesCalculator function
+// instead.
+type TypesCalculator struct {
+}
+
+// NewTypesCalculator creates a new types calculator.
+func NewTypesCalculator() *TypesCalculator {
+	return &TypesCalculator{}
+}
+
+// TypeScriptReference returns the TypeScript type expression that should be used to reference the
+// given type, for example a list of strings becomes 'string[]' and a map of clusters becomes
+// '{[k: string]: Cluster}'. A scalar becomes its plain TypeScript type, for example 'string';
+// no '| undefined' is added to it.
+func (c *TypesCalculator) TypeScriptReference(typ *concepts.Type) string {
+	switch {
+	case typ.IsScalar():
+		return c.scalarReference(typ)
+	case typ.IsEnum(), typ.IsStruct():
+		return objectName(typ)
+	case typ.IsList():
+		return fmt.Sprintf("%s[]", c.TypeScriptReference(typ.Element()))
+	case typ.IsMap():
+		return fmt.Sprintf("{[k: string]: %s}", c.TypeScriptReference(typ.Element()))
+	default:
+		return "any"
+	}
+}
+
+func (c *TypesCalculator) scalarReference(typ *concepts.Type) string {
+	switch typ.Name().String() {
+	case "string", "date":
+		return "string"
+	case "boolean":
+		return "boolean"
+	case "integer", "long", "float":
+		return "number"
+	case "interface":
+		return "any"
+	default:
+		return "string"
+	}
+}